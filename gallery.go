@@ -0,0 +1,99 @@
+// File: gallery.go
+
+package gollm
+
+import (
+	"fmt"
+
+	"gollm/internal/gallery"
+	"gollm/internal/llm"
+)
+
+// loadedGallery holds the manifest most recently loaded via LoadGallery, so
+// ListModels and NewLLMFromGallery can be called by name alone afterwards.
+var loadedGallery *gallery.Manifest
+
+// LoadGallery reads a gallery manifest (a local file path or an http(s) URL)
+// describing available {name, provider, model, endpoint, default_options,
+// prompt_template} entries, making its models available to ListModels and
+// NewLLMFromGallery. A manifest lets one file describe dozens of ZhiPu/
+// TongYi/openai_compatible endpoints instead of hardcoding providers.
+func LoadGallery(source string) error {
+	manifest, err := gallery.Load(source)
+	if err != nil {
+		return fmt.Errorf("error loading gallery: %w", err)
+	}
+	registerGalleryProviders(manifest)
+	loadedGallery = manifest
+	return nil
+}
+
+// registerGalleryProviders registers each openai_compatible entry's endpoint
+// with providerRegistry under the entry's own name, so a manifest can
+// describe several distinct self-hosted backends and have NewLLMFromGallery
+// select each one directly instead of sharing the single generic
+// "openai_compatible" provider's endpoint.
+func registerGalleryProviders(manifest *gallery.Manifest) {
+	for _, entry := range manifest.Models {
+		if entry.Provider == "openai_compatible" && entry.Endpoint != "" {
+			llm.RegisterOpenAICompatibleEndpoint(providerRegistry, entry.Name, entry.Endpoint)
+		}
+	}
+}
+
+// ListModels returns the names of every model in the gallery loaded via
+// LoadGallery.
+func ListModels() ([]string, error) {
+	if loadedGallery == nil {
+		return nil, fmt.Errorf("no gallery loaded: call LoadGallery first")
+	}
+	return loadedGallery.Names(), nil
+}
+
+// NewLLMFromGallery builds an LLM from the named gallery entry: it sets the
+// provider and model, dials the entry's endpoint for providers that support
+// a configurable endpoint (e.g. ollama), and applies the entry's
+// default_options via SetOption. openai_compatible entries instead select
+// the per-entry provider registerGalleryProviders registered under the
+// entry's own name, since its endpoint is already baked in there. If the
+// entry has a prompt_template, the returned LLM's Generate applies it to the
+// prompt text before sending.
+func NewLLMFromGallery(name string) (LLM, error) {
+	if loadedGallery == nil {
+		return nil, fmt.Errorf("no gallery loaded: call LoadGallery first")
+	}
+
+	entry, ok := loadedGallery.Find(name)
+	if !ok {
+		return nil, fmt.Errorf("model %q not found in gallery", name)
+	}
+
+	provider := entry.Provider
+	registeredByName := entry.Provider == "openai_compatible" && entry.Endpoint != ""
+	if registeredByName {
+		provider = entry.Name
+	}
+
+	l, err := NewLLM(SetProvider(provider), SetModel(entry.Model))
+	if err != nil {
+		return nil, fmt.Errorf("error creating LLM for gallery model %q: %w", name, err)
+	}
+
+	if entry.PromptTemplate != "" {
+		if impl, ok := l.(*llmImpl); ok {
+			impl.promptTemplate = entry.PromptTemplate
+		}
+	}
+
+	if entry.Endpoint != "" && !registeredByName {
+		if err := l.SetOllamaEndpoint(entry.Endpoint); err != nil {
+			return nil, fmt.Errorf("error setting endpoint for gallery model %q: %w", name, err)
+		}
+	}
+
+	for k, v := range entry.DefaultOptions {
+		l.SetOption(k, v)
+	}
+
+	return l, nil
+}
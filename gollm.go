@@ -4,18 +4,39 @@ package gollm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"gollm/internal/gallery"
 	"gollm/internal/llm"
 )
 
+// LogLevel is a thin alias for slog.Level, kept so callers that imported
+// gollm before the switch to log/slog don't need to change their code.
+type LogLevel = slog.Level
+
+const (
+	LogLevelDebug = LogLevel(slog.LevelDebug)
+	LogLevelInfo  = LogLevel(slog.LevelInfo)
+	LogLevelWarn  = LogLevel(slog.LevelWarn)
+	LogLevelError = LogLevel(slog.LevelError)
+)
+
 // LLM is the interface that wraps the basic LLM operations
 type LLM interface {
 	// Generate produces a response given a context, prompt, and optional generate options
 	Generate(ctx context.Context, prompt *Prompt, opts ...GenerateOption) (string, error)
 
+	// Stream produces a response incrementally, emitting token deltas over the
+	// returned channel as they arrive. Returns an error if the current
+	// provider does not support streaming.
+	Stream(ctx context.Context, prompt *Prompt, opts ...GenerateOption) (<-chan StreamChunk, error)
+
 	// SetOption sets an option for the LLM
 	SetOption(key string, value interface{})
 
@@ -38,15 +59,30 @@ type LLM interface {
 	GetDebugLevel() LogLevel
 
 	SetOllamaEndpoint(endpoint string) error
+
+	// SetGRPCEndpoint dials an out-of-process model backend for providers
+	// that support driving generation over gRPC (see internal/llm/grpc)
+	SetGRPCEndpoint(addr string) error
+
+	// GenerateImage produces one or more images for prompt through image
+	// models such as cogview-3 or DALL-E. Providers that only support text
+	// generation return ErrUnsupported so callers can feature-detect.
+	GenerateImage(ctx context.Context, prompt string, opts ImageOptions) ([]ImageResult, error)
 }
 
 // llmImpl is the concrete implementation of the LLM interface
 type llmImpl struct {
 	llm.LLM  // Embedded LLM interface from internal package
-	logger   llm.Logger
+	logger   *slog.Logger
+	levelVar *slog.LevelVar // backs the default logger's handler, so UpdateDebugLevel can swap levels atomically
 	provider string
 	model    string
 	config   *Config
+
+	// promptTemplate is the gallery entry's prompt_template, set by
+	// NewLLMFromGallery; Generate applies it to the prompt text before
+	// sending. Empty for LLMs built directly through NewLLM.
+	promptTemplate string
 }
 
 // GenerateOption is a function type for configuring generate options
@@ -55,6 +91,7 @@ type GenerateOption func(*generateConfig)
 // generateConfig holds configuration options for the Generate method
 type generateConfig struct {
 	useJSONSchema bool
+	functions     []FunctionDef
 }
 
 // WithJSONSchemaValidation returns a GenerateOption that enables JSON schema validation
@@ -64,6 +101,28 @@ func WithJSONSchemaValidation() GenerateOption {
 	}
 }
 
+// FunctionDef describes a single callable function/tool the LLM may invoke
+// instead of answering in free text.
+type FunctionDef = llm.FunctionDef
+
+// WithFunctions returns a GenerateOption that offers the LLM a set of
+// callable functions. Providers that understand tool-calling (OpenAI, ZhiPu,
+// TongYi) receive an OpenAI-style "tools" payload; providers that constrain
+// decoding via a grammar also receive a BNF-like "grammar" option derived
+// from each function's JSON schema. Use ParseFunctionCall to pull the
+// resulting call out of the response.
+func WithFunctions(fns ...FunctionDef) GenerateOption {
+	return func(c *generateConfig) {
+		c.functions = fns
+	}
+}
+
+// ParseFunctionCall extracts the function name and arguments from a response
+// produced under WithFunctions, regardless of which provider returned it.
+func ParseFunctionCall(response string) (name string, args json.RawMessage, err error) {
+	return llm.ParseFunctionCall(response)
+}
+
 // GetProvider returns the provider of the LLM
 func (l *llmImpl) GetProvider() string {
 	return l.provider
@@ -87,6 +146,13 @@ func (l *llmImpl) GetDebugLevel() LogLevel {
 // Type aliases to bridge public and internal types
 type Metric = llm.Metric
 type OptimizationEntry = llm.OptimizationEntry
+type StreamChunk = llm.StreamChunk
+type ImageResult = llm.ImageResult
+type ImageOptions = llm.ImageOptions
+
+// ErrUnsupported is returned by optional-capability methods (such as
+// GenerateImage) when the configured provider doesn't implement them.
+var ErrUnsupported = llm.ErrUnsupported
 
 // OptimizerOption is a function type for configuring the PromptOptimizer
 type OptimizerOption func(*PromptOptimizer)
@@ -94,12 +160,43 @@ type OptimizerOption func(*PromptOptimizer)
 // IterationCallback is a function type for the iteration callback
 type IterationCallback func(iteration int, entry OptimizationEntry)
 
+// BanditOptimizationEntry extends OptimizationEntry with the bandit arm that
+// produced it and that arm's lower confidence bound at the time, for callers
+// using WithStrategy(StrategyBandit) who want to inspect the pool's UCB1
+// bookkeeping rather than just the refined prompt.
+type BanditOptimizationEntry struct {
+	OptimizationEntry
+	ArmID                string
+	LowerConfidenceBound float64
+}
+
+// banditMutationDirectives are appended to the pool's current best candidate
+// in turn as the bandit pool grows, so each new arm explores a textually
+// distinct variant of the best prompt seen so far.
+var banditMutationDirectives = []string{
+	"Be more concise.",
+	"Be more specific and detailed.",
+	"Emphasize accuracy and correctness.",
+}
+
 // PromptOptimizer is the public interface for the prompt optimization system
 type PromptOptimizer struct {
 	internal   *llm.PromptOptimizer
 	callback   IterationCallback
 	memorySize int
 	verbose    bool
+	strategy   OptimizerStrategy
+	threshold  float64
+	iterations int
+
+	// Populated by NewPromptOptimizer; consulted by OptimizePrompt only when
+	// strategy == StrategyBandit, to build a fresh per-arm *llm.PromptOptimizer
+	// for each pull.
+	llmCore       llm.LLM
+	debugManager  *llm.DebugManager
+	taskDesc      string
+	pool          *llm.BanditPool
+	banditHistory []BanditOptimizationEntry
 }
 
 func WithVerbose() OptimizerOption {
@@ -145,6 +242,7 @@ func WithMemorySize(size int) OptimizerOption {
 
 func WithIterations(iterations int) OptimizerOption {
 	return func(po *PromptOptimizer) {
+		po.iterations = iterations
 		po.internal.WithIterations(iterations)
 	}
 }
@@ -173,8 +271,15 @@ func defaultVerboseCallback(iteration int, entry OptimizationEntry) {
 	fmt.Printf("%s\n", strings.Repeat("-", 50))
 }
 
-// OptimizePrompt runs the optimization process
+// OptimizePrompt runs the optimization process. Under the default
+// StrategySequential it delegates to po.internal's assess -> rewrite loop;
+// under StrategyBandit it instead drives po.pool with UCB1 (see
+// optimizePromptBandit).
 func (po *PromptOptimizer) OptimizePrompt(ctx context.Context) (string, error) {
+	if po.strategy == StrategyBandit {
+		return po.optimizePromptBandit(ctx)
+	}
+
 	optimizedPrompt, err := po.internal.OptimizePrompt(ctx)
 	if err != nil {
 		return "", fmt.Errorf("optimization failed: %w", err)
@@ -182,6 +287,95 @@ func (po *PromptOptimizer) OptimizePrompt(ctx context.Context) (string, error) {
 	return optimizedPrompt.Input, nil
 }
 
+// assessmentScoreScale is the upper bound of Assessment.OverallScore, the
+// same raw, non-[0,1] value defaultVerboseCallback prints with "Overall
+// Score: %.2f" next to a letter OverallGrade (a 0-100 scale, not a fraction).
+// normalizeAssessmentScore divides it down to BanditPool's expected [0,1]
+// scale before folding it into an arm's running mean.
+const assessmentScoreScale = 100
+
+// normalizeAssessmentScore maps a raw Assessment.OverallScore onto [0,1] so
+// it's comparable to the exploration bonus in BanditArm.UCB1/
+// LowerConfidenceBound, which assume a [0,1] score.
+func normalizeAssessmentScore(score float64) float64 {
+	normalized := score / assessmentScoreScale
+	switch {
+	case normalized < 0:
+		return 0
+	case normalized > 1:
+		return 1
+	default:
+		return normalized
+	}
+}
+
+// optimizePromptBandit runs po.iterations pulls of po.pool. Each pull selects
+// the arm with the highest UCB1 score, refines it for a single iteration
+// through a fresh *llm.PromptOptimizer scoped to that arm's prompt text, and
+// folds the resulting assessment score back into the pool. The pool grows by
+// one mutated arm per pull (up to MaxSize) and stops early once the best
+// arm's LowerConfidenceBound crosses po.threshold.
+func (po *PromptOptimizer) optimizePromptBandit(ctx context.Context) (string, error) {
+	rounds := po.iterations
+	if rounds <= 0 {
+		rounds = 5
+	}
+
+	for i := 0; i < rounds; i++ {
+		arm := po.pool.Select()
+
+		armOptimizer := llm.NewPromptOptimizer(po.llmCore, po.debugManager, &llm.Prompt{Input: arm.Prompt}, po.taskDesc)
+		armOptimizer.WithIterations(1)
+
+		if _, err := armOptimizer.OptimizePrompt(ctx); err != nil {
+			return "", fmt.Errorf("bandit optimization failed on arm %s: %w", arm.ID, err)
+		}
+
+		history := armOptimizer.GetOptimizationHistory()
+		var score float64
+		if len(history) > 0 {
+			entry := history[len(history)-1]
+			score = normalizeAssessmentScore(entry.Assessment.OverallScore)
+			arm.Prompt = entry.Prompt.Input
+
+			po.pool.Update(arm, score)
+			lcb := arm.LowerConfidenceBound(po.pool.TotalPulls())
+			banditEntry := BanditOptimizationEntry{
+				OptimizationEntry:    OptimizationEntry(entry),
+				ArmID:                arm.ID,
+				LowerConfidenceBound: lcb,
+			}
+			po.banditHistory = append(po.banditHistory, banditEntry)
+
+			if po.callback != nil {
+				po.callback(i, banditEntry.OptimizationEntry)
+			} else if po.verbose {
+				defaultVerboseCallback(i, banditEntry.OptimizationEntry)
+			}
+		} else {
+			po.pool.Update(arm, score)
+		}
+
+		if (i+1)%po.pool.MutationInterval == 0 {
+			directive := banditMutationDirectives[i%len(banditMutationDirectives)]
+			po.pool.Evolve(func(best string) string { return best + "\n" + directive })
+		}
+
+		if po.threshold > 0 && po.pool.Best().LowerConfidenceBound(po.pool.TotalPulls()) >= po.threshold {
+			break
+		}
+	}
+
+	return po.pool.Best().Prompt, nil
+}
+
+// GetBanditOptimizationHistory returns the per-pull history recorded while
+// OptimizePrompt ran under StrategyBandit. It's empty under the default
+// StrategySequential; use GetOptimizationHistory for that case instead.
+func (po *PromptOptimizer) GetBanditOptimizationHistory() []BanditOptimizationEntry {
+	return po.banditHistory
+}
+
 // NewPromptOptimizer creates a new PromptOptimizer
 func NewPromptOptimizer(l LLM, initialPrompt string, taskDesc string, opts ...OptimizerOption) *PromptOptimizer {
 	internalLLM, ok := l.(*llmImpl)
@@ -203,6 +397,10 @@ func NewPromptOptimizer(l LLM, initialPrompt string, taskDesc string, opts ...Op
 		internal:   llm.NewPromptOptimizer(internalLLM.LLM, debugManager, internalPrompt, taskDesc),
 		memorySize: 2,
 		verbose:    false, // Default to false
+
+		llmCore:      internalLLM.LLM,
+		debugManager: debugManager,
+		taskDesc:     taskDesc,
 	}
 
 	for _, opt := range opts {
@@ -220,6 +418,10 @@ func NewPromptOptimizer(l LLM, initialPrompt string, taskDesc string, opts ...Op
 		})
 	}
 
+	if po.strategy == StrategyBandit {
+		po.pool = llm.NewBanditPool(initialPrompt, nil, 4, 1)
+	}
+
 	return po
 }
 
@@ -249,13 +451,35 @@ func WithRatingSystem(system string) OptimizerOption {
 	}
 }
 
-// WithThreshold sets the threshold for the PromptOptimizer
+// WithThreshold sets the threshold for the PromptOptimizer. Under
+// StrategyBandit this also doubles as the bandit's stopping criterion:
+// OptimizePrompt stops pulling arms once the best arm's
+// LowerConfidenceBound crosses threshold.
 func WithThreshold(threshold float64) OptimizerOption {
 	return func(po *PromptOptimizer) {
+		po.threshold = threshold
 		po.internal.WithThreshold(threshold)
 	}
 }
 
+// OptimizerStrategy selects how OptimizePrompt chooses its next candidate.
+type OptimizerStrategy = llm.OptimizerStrategy
+
+// StrategyBandit replaces the default sequential assess -> rewrite loop with
+// a UCB1 multi-armed bandit over a pool of candidate prompts (see
+// llm.BanditPool), which gives better sample efficiency than the greedy loop
+// when each LLM call is expensive.
+const StrategyBandit = llm.StrategyBandit
+
+// WithStrategy selects the optimization strategy. Pass StrategyBandit to
+// have OptimizePrompt maintain a pool of candidate prompts and pick the next
+// one to try via UCB1 instead of refining a single candidate in place.
+func WithStrategy(strategy OptimizerStrategy) OptimizerOption {
+	return func(po *PromptOptimizer) {
+		po.strategy = strategy
+	}
+}
+
 // SetOption sets an option for the LLM with the given key and value
 func (l *llmImpl) SetOption(key string, value interface{}) {
 	// Log the attempt to set an option
@@ -276,6 +500,16 @@ func (l *llmImpl) SetOllamaEndpoint(endpoint string) error {
 	return fmt.Errorf("current provider does not support setting custom endpoint")
 }
 
+// SetGRPCEndpoint dials an out-of-process model backend for providers that
+// embed a grpc_external connection, mirroring SetOllamaEndpoint's pattern for
+// providers with a configurable endpoint.
+func (l *llmImpl) SetGRPCEndpoint(addr string) error {
+	if p, ok := l.LLM.(interface{ SetGRPCEndpoint(string) error }); ok {
+		return p.SetGRPCEndpoint(addr)
+	}
+	return fmt.Errorf("current provider does not support a grpc endpoint")
+}
+
 func (l *llmImpl) ClearMemory() {
 	if llmWithMemory, ok := l.LLM.(*llm.LLMWithMemory); ok {
 		llmWithMemory.ClearMemory()
@@ -300,14 +534,18 @@ func (l *llmImpl) GetPromptJSONSchema(opts ...SchemaOption) ([]byte, error) {
 	return p.GenerateJSONSchema(opts...)
 }
 
-// UpdateDebugLevel updates the debug level for both the gollm package and the internal llm package
+// UpdateDebugLevel updates the debug level for both the gollm package and the
+// internal llm package. The level is swapped atomically on the shared
+// slog.LevelVar backing the default logger's handler, so concurrent log calls
+// never observe a torn update. A logger supplied through WithLogger is not
+// backed by that LevelVar and keeps its own level.
 func (l *llmImpl) UpdateDebugLevel(level LogLevel) {
 	l.logger.Debug("Updating debug level",
 		"current_level", l.config.DebugLevel,
 		"new_level", level)
 
 	l.config.DebugLevel = level
-	l.logger.SetLevel(llm.LogLevel(level))
+	l.levelVar.Set(level)
 
 	if internalLLM, ok := l.LLM.(interface{ SetDebugLevel(llm.LogLevel) }); ok {
 		internalLLM.SetDebugLevel(llm.LogLevel(level))
@@ -319,20 +557,11 @@ func (l *llmImpl) UpdateDebugLevel(level LogLevel) {
 	l.logger.Debug("Debug level updated successfully")
 }
 
-// CleanResponse removes markdown code block syntax and trims the JSON response
+// CleanResponse removes markdown code block syntax and trims the JSON
+// response. ParseFunctionCall extracts a function call's JSON the same way,
+// via the shared llm.ExtractJSONObject helper.
 func CleanResponse(response string) string {
-	// Remove markdown code block syntax if present
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimSuffix(response, "```")
-
-	// Remove any text before the first '{' and after the last '}'
-	start := strings.Index(response, "{")
-	end := strings.LastIndex(response, "}")
-	if start != -1 && end != -1 && end > start {
-		response = response[start : end+1]
-	}
-
-	return strings.TrimSpace(response)
+	return llm.ExtractJSONObject(response)
 }
 
 func (l *llmImpl) Generate(ctx context.Context, prompt *Prompt, opts ...GenerateOption) (string, error) {
@@ -355,7 +584,9 @@ func (l *llmImpl) Generate(ctx context.Context, prompt *Prompt, opts ...Generate
 
 	// Ensure logger is initialized
 	if l.logger == nil {
-		l.logger = llm.NewLogger(llm.LogLevel(LogLevelWarn))
+		l.levelVar = &slog.LevelVar{}
+		l.levelVar.Set(LogLevelWarn)
+		l.logger = newDedupLogger(l.levelVar)
 		l.logger.Warn("Logger was nil, created new logger with WARN level")
 	}
 
@@ -377,12 +608,53 @@ func (l *llmImpl) Generate(ctx context.Context, prompt *Prompt, opts ...Generate
 		l.logger.Debug("Prompt validation successful")
 	}
 
+	// Offer the LLM a set of callable functions, if requested. "tools" and
+	// "grammar" only apply to this call, so reset them once Generate returns
+	// instead of leaving them set on l for every call after this one.
+	if len(config.functions) > 0 {
+		l.logger.Debug("Setting function-calling options", "count", len(config.functions))
+		l.SetOption("tools", llm.FunctionsToTools(config.functions))
+		defer l.SetOption("tools", nil)
+		if grammar := llm.FunctionsToGrammar(config.functions); grammar != "" {
+			l.SetOption("grammar", grammar)
+			defer l.SetOption("grammar", nil)
+		}
+	}
+
+	// Apply the gallery entry's prompt_template, if NewLLMFromGallery set one,
+	// so a manifest can shape what's actually sent instead of just the raw
+	// prompt text.
+	promptText := prompt.String()
+	if l.promptTemplate != "" {
+		rendered, err := gallery.RenderTemplate(l.provider, l.promptTemplate, promptText)
+		if err != nil {
+			l.logger.Error("Error rendering prompt template", "error", err)
+			return "", fmt.Errorf("error rendering prompt template: %w", err)
+		}
+		promptText = rendered
+	}
+
 	// Send prompt to LLM
 	l.logger.Debug("Sending prompt to LLM",
-		"prompt", prompt.String(),
+		"prompt", promptText,
 		"provider", l.GetProvider(),
 		"model", l.GetModel())
-	response, fullPrompt, err := l.LLM.Generate(ctx, prompt.String())
+
+	// GRPCProvider's Provider methods describe an HTTP request shape that
+	// going out over Endpoint() as an HTTP call can't satisfy, so route
+	// blocking Generate straight to its gRPC client instead of through
+	// l.LLM.Generate's HTTP path. Asserting for llm.GRPCGenerateProvider's
+	// dedicated GenerateGRPC method reaches it the same way SetGRPCEndpoint
+	// already does, rather than asserting a second Generate signature that
+	// could never be satisfied alongside l.LLM's own Generate.
+	var response, fullPrompt string
+	var err error
+	if grpcProvider, ok := l.LLM.(llm.GRPCGenerateProvider); ok {
+		fullPrompt = promptText
+		response, err = grpcProvider.GenerateGRPC(ctx, promptText, nil)
+	} else {
+		response, fullPrompt, err = l.LLM.Generate(ctx, promptText)
+	}
 	if err != nil {
 		l.logger.Error("Error from LLM.Generate",
 			"error", err,
@@ -401,6 +673,142 @@ func (l *llmImpl) Generate(ctx context.Context, prompt *Prompt, opts ...Generate
 	return cleanedResponse, nil
 }
 
+// Stream produces a response incrementally over the returned channel instead
+// of waiting for the full completion. It requires the configured provider to
+// implement llm.StreamingProvider; providers that don't support it return an
+// error so callers can fall back to Generate.
+func (l *llmImpl) Stream(ctx context.Context, prompt *Prompt, opts ...GenerateOption) (<-chan StreamChunk, error) {
+	l.logger.Debug("Starting Stream method",
+		"prompt_length", len(prompt.String()),
+		"provider", l.GetProvider(),
+		"model", l.GetModel())
+
+	config := &generateConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.useJSONSchema {
+		if err := llm.Validate(prompt); err != nil {
+			l.logger.Error("Prompt validation failed", "error", err)
+			return nil, fmt.Errorf("invalid prompt: %w", err)
+		}
+	}
+
+	if provider, ok := l.LLM.(llm.StreamingProvider); ok {
+		body, err := provider.PrepareStreamRequest(prompt.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error preparing stream request: %w", err)
+		}
+		return llm.StreamRequest(ctx, provider, body)
+	}
+
+	if provider, ok := l.LLM.(llm.GRPCStreamingProvider); ok {
+		return provider.StreamGRPC(ctx, prompt.String(), nil)
+	}
+
+	return nil, fmt.Errorf("provider %q does not support streaming", l.GetProvider())
+}
+
+// GenerateImage produces one or more images for prompt, routing cogview-3,
+// DALL-E, and similar models through the provider's llm.ImageProvider
+// implementation. Text-only providers return ErrUnsupported.
+func (l *llmImpl) GenerateImage(ctx context.Context, prompt string, opts ImageOptions) ([]ImageResult, error) {
+	l.logger.Debug("Starting GenerateImage method",
+		"provider", l.GetProvider(),
+		"model", l.GetModel())
+
+	imageProvider, ok := l.LLM.(llm.ImageProvider)
+	if !ok {
+		return nil, llm.ErrUnsupported
+	}
+
+	return imageProvider.GenerateImage(ctx, prompt, opts)
+}
+
+// WithLogger overrides the LLM's logger with a caller-supplied *slog.Logger,
+// e.g. to route gollm's logs through an application's existing slog
+// configuration. When unset, NewLLM builds its own logger around a
+// llm.DedupHandler so repeated retry/rate-limit records during
+// PromptOptimizer iterations don't flood output.
+func WithLogger(logger *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.logger = logger
+	}
+}
+
+// dedupFlushInterval is how often the default logger's DedupHandler is
+// flushed, emitting a "repeated N times" summary for whatever it suppressed
+// in the last window.
+const dedupFlushInterval = 30 * time.Second
+
+// newDedupLogger builds the default slog.Logger used when no WithLogger
+// option is supplied: text output to stderr, deduplicated within a 30s
+// window, at the level tracked by levelVar. The handler is registered with
+// the single process-wide flush goroutine (see registerDedupHandler) instead
+// of starting one per call, so suppressed repeats are still summarized
+// without leaking a ticker goroutine every time NewLLM runs.
+func newDedupLogger(levelVar *slog.LevelVar) *slog.Logger {
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: levelVar})
+	dedup := llm.NewDedupHandler(handler, dedupFlushInterval)
+	registerDedupHandler(dedup)
+	return slog.New(dedup)
+}
+
+var (
+	dedupFlushStart sync.Once
+	dedupHandlersMu sync.Mutex
+	dedupHandlers   []*llm.DedupHandler
+)
+
+// registerDedupHandler adds dedup to the set flushed by the process-wide
+// flush goroutine, starting that goroutine on the first call. Every
+// newDedupLogger call shares the same goroutine rather than spawning its
+// own, so the number of background goroutines stays at one for the life of
+// the process no matter how many loggers get created.
+func registerDedupHandler(dedup *llm.DedupHandler) {
+	dedupHandlersMu.Lock()
+	dedupHandlers = append(dedupHandlers, dedup)
+	dedupHandlersMu.Unlock()
+
+	dedupFlushStart.Do(func() {
+		go runDedupFlush()
+	})
+}
+
+// runDedupFlush flushes every registered DedupHandler on each tick, for the
+// life of the process.
+func runDedupFlush() {
+	ticker := time.NewTicker(dedupFlushInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		dedupHandlersMu.Lock()
+		handlers := append([]*llm.DedupHandler(nil), dedupHandlers...)
+		dedupHandlersMu.Unlock()
+
+		for _, dedup := range handlers {
+			dedup.Flush(context.Background())
+		}
+	}
+}
+
+// providerRegistry is shared by every NewLLM call and by the gallery loader
+// (see gallery.go), so provider/endpoint pairs a gallery manifest registers
+// are available the next time NewLLM resolves config.Provider, not just to
+// the built-in zhipu/tongyi/grpc_external/openai_compatible providers.
+var providerRegistry = newProviderRegistry()
+
+// newProviderRegistry builds the registry shared by every NewLLM call,
+// registering grpc_external and openai_compatible alongside whatever
+// built-ins llm.NewProviderRegistry already carries, so SetProvider
+// ("grpc_external"/"openai_compatible") resolves without callers having to
+// register them by hand.
+func newProviderRegistry() *llm.ProviderRegistry {
+	registry := llm.NewProviderRegistry()
+	llm.RegisterGRPCExternalProvider(registry)
+	llm.RegisterOpenAICompatibleProvider(registry)
+	return registry
+}
+
 // NewLLM creates a new LLM instance, potentially with memory if the option is set
 func NewLLM(opts ...ConfigOption) (LLM, error) {
 	config, err := LoadConfig()
@@ -412,7 +820,13 @@ func NewLLM(opts ...ConfigOption) (LLM, error) {
 		opt(config)
 	}
 
-	logger := llm.NewLogger(llm.LogLevel(config.DebugLevel))
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(config.DebugLevel)
+
+	logger := config.logger
+	if logger == nil {
+		logger = newDedupLogger(levelVar)
+	}
 
 	internalConfig := config.toInternalConfig()
 
@@ -421,7 +835,7 @@ func NewLLM(opts ...ConfigOption) (LLM, error) {
 		internalConfig.OllamaEndpoint = config.OllamaEndpoint
 	}
 
-	baseLLM, err := llm.NewLLM(internalConfig, logger, llm.NewProviderRegistry())
+	baseLLM, err := llm.NewLLM(internalConfig, logger, providerRegistry)
 	if err != nil {
 		logger.Error("Failed to create internal LLM", "error", err)
 		return nil, fmt.Errorf("failed to create internal LLM: %w", err)
@@ -436,6 +850,7 @@ func NewLLM(opts ...ConfigOption) (LLM, error) {
 		return &llmImpl{
 			LLM:      llmWithMemory,
 			logger:   logger,
+			levelVar: levelVar,
 			provider: config.Provider,
 			model:    config.Model,
 			config:   config,
@@ -445,6 +860,7 @@ func NewLLM(opts ...ConfigOption) (LLM, error) {
 	return &llmImpl{
 		LLM:      baseLLM,
 		logger:   logger,
+		levelVar: levelVar,
 		provider: config.Provider,
 		model:    config.Model,
 		config:   config,
@@ -0,0 +1,90 @@
+// File: internal/gallery/gallery.go
+
+// Package gallery loads a YAML manifest describing available provider/model
+// combinations, so callers can ship one file describing dozens of endpoints
+// instead of hardcoding providers.
+package gallery
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Entry describes a single named model in the gallery.
+type Entry struct {
+	Name           string                 `yaml:"name"`
+	Provider       string                 `yaml:"provider"`
+	Model          string                 `yaml:"model"`
+	Endpoint       string                 `yaml:"endpoint"`
+	DefaultOptions map[string]interface{} `yaml:"default_options"`
+	PromptTemplate string                 `yaml:"prompt_template"`
+}
+
+// Manifest is the top-level shape of a gallery YAML file.
+type Manifest struct {
+	Models []Entry `yaml:"models"`
+}
+
+// Find returns the entry with the given name, if present.
+func (m *Manifest) Find(name string) (Entry, bool) {
+	for _, e := range m.Models {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Names returns every model name in the manifest, in manifest order.
+func (m *Manifest) Names() []string {
+	names := make([]string, len(m.Models))
+	for i, e := range m.Models {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// Load reads a manifest from source, which may be a local file path or an
+// http(s) URL.
+func Load(source string) (*Manifest, error) {
+	data, err := read(source)
+	if err != nil {
+		return nil, fmt.Errorf("error loading gallery manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("error parsing gallery manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+func read(source string) ([]byte, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching manifest: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("error fetching manifest: status %d", resp.StatusCode)
+		}
+
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, resp.Body); err != nil {
+			return nil, fmt.Errorf("error reading manifest response: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	return os.ReadFile(source)
+}
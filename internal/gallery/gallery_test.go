@@ -0,0 +1,91 @@
+// File: internal/gallery/gallery_test.go
+
+package gallery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testManifest = `
+models:
+  - name: local-llama
+    provider: openai_compatible
+    model: llama-3-8b
+    endpoint: http://localhost:8080/v1/chat/completions
+    default_options:
+      temperature: 0.7
+  - name: zhipu-glm4
+    provider: zhipu
+    model: glm-4
+`
+
+func writeTestManifest(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gallery.yaml")
+	if err := os.WriteFile(path, []byte(testManifest), 0o644); err != nil {
+		t.Fatalf("error writing test manifest: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFile(t *testing.T) {
+	manifest, err := Load(writeTestManifest(t))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if len(manifest.Models) != 2 {
+		t.Fatalf("len(manifest.Models) = %d, want 2", len(manifest.Models))
+	}
+	if manifest.Models[0].Endpoint != "http://localhost:8080/v1/chat/completions" {
+		t.Errorf("unexpected endpoint: %q", manifest.Models[0].Endpoint)
+	}
+	if manifest.Models[0].DefaultOptions["temperature"] != 0.7 {
+		t.Errorf("unexpected default_options: %v", manifest.Models[0].DefaultOptions)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load("/nonexistent/path/gallery.yaml"); err == nil {
+		t.Error("expected an error loading a nonexistent manifest")
+	}
+}
+
+func TestManifestFind(t *testing.T) {
+	manifest, err := Load(writeTestManifest(t))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	entry, ok := manifest.Find("zhipu-glm4")
+	if !ok {
+		t.Fatal("Find did not locate an entry that exists in the manifest")
+	}
+	if entry.Model != "glm-4" {
+		t.Errorf("Model = %q, want glm-4", entry.Model)
+	}
+
+	if _, ok := manifest.Find("does-not-exist"); ok {
+		t.Error("Find should report ok=false for a name not in the manifest")
+	}
+}
+
+func TestManifestNames(t *testing.T) {
+	manifest, err := Load(writeTestManifest(t))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	names := manifest.Names()
+	want := []string{"local-llama", "zhipu-glm4"}
+	if len(names) != len(want) {
+		t.Fatalf("Names() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Names()[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
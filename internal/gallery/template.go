@@ -0,0 +1,42 @@
+// File: internal/gallery/template.go
+
+package gallery
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// RenderPrompt applies the entry's prompt_template (a Go text/template) to
+// data, returning the literal prompt to send to the model. Entries without a
+// template are a no-op: data must itself be the prompt string.
+func (e Entry) RenderPrompt(data interface{}) (string, error) {
+	if e.PromptTemplate == "" {
+		prompt, ok := data.(string)
+		if !ok {
+			return "", fmt.Errorf("entry %q has no prompt_template and data is not a string", e.Name)
+		}
+		return prompt, nil
+	}
+
+	return RenderTemplate(e.Name, e.PromptTemplate, data)
+}
+
+// RenderTemplate parses src as a Go text/template named name and executes it
+// against data, returning the rendered text. Factored out of RenderPrompt so
+// callers outside the gallery package (gollm.Generate, applying a loaded
+// entry's prompt_template to the outgoing prompt) can render without
+// constructing an Entry.
+func RenderTemplate(name, src string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("error parsing prompt template for %q: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering prompt template for %q: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,56 @@
+// File: internal/gallery/template_test.go
+
+package gallery
+
+import "testing"
+
+func TestRenderPromptNoTemplate(t *testing.T) {
+	e := Entry{Name: "plain"}
+	got, err := e.RenderPrompt("hello there")
+	if err != nil {
+		t.Fatalf("RenderPrompt returned error: %v", err)
+	}
+	if got != "hello there" {
+		t.Errorf("RenderPrompt() = %q, want %q", got, "hello there")
+	}
+}
+
+func TestRenderPromptNoTemplateNonString(t *testing.T) {
+	e := Entry{Name: "plain"}
+	if _, err := e.RenderPrompt(42); err == nil {
+		t.Error("expected an error when data is not a string and there's no prompt_template")
+	}
+}
+
+func TestRenderPromptWithTemplate(t *testing.T) {
+	e := Entry{Name: "templated", PromptTemplate: "Hello, {{.Name}}! You are {{.Age}} years old."}
+	got, err := e.RenderPrompt(struct {
+		Name string
+		Age  int
+	}{Name: "Ada", Age: 30})
+	if err != nil {
+		t.Fatalf("RenderPrompt returned error: %v", err)
+	}
+	want := "Hello, Ada! You are 30 years old."
+	if got != want {
+		t.Errorf("RenderPrompt() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderPromptBadTemplate(t *testing.T) {
+	e := Entry{Name: "broken", PromptTemplate: "{{.Unclosed"}
+	if _, err := e.RenderPrompt(nil); err == nil {
+		t.Error("expected an error parsing a malformed prompt_template")
+	}
+}
+
+func TestRenderTemplateStandalone(t *testing.T) {
+	got, err := RenderTemplate("standalone", "Answer concisely: {{.}}", "what is Go?")
+	if err != nil {
+		t.Fatalf("RenderTemplate returned error: %v", err)
+	}
+	want := "Answer concisely: what is Go?"
+	if got != want {
+		t.Errorf("RenderTemplate() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+// File: internal/llm/bandit.go
+
+package llm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// OptimizerStrategy selects how PromptOptimizer chooses its next candidate
+// each iteration.
+type OptimizerStrategy int
+
+const (
+	// StrategySequential is the default assess -> rewrite loop: one
+	// candidate is refined in place each iteration.
+	StrategySequential OptimizerStrategy = iota
+	// StrategyBandit runs a UCB1 multi-armed bandit over a pool of
+	// candidate prompts instead, trading a larger per-iteration candidate
+	// pool for better sample efficiency when each LLM call is expensive.
+	StrategyBandit
+)
+
+// BanditArm is one candidate prompt variant in a BanditPool, along with the
+// running statistics UCB1 needs to decide whether to keep exploring it.
+type BanditArm struct {
+	ID        string
+	Prompt    string
+	Pulls     int
+	MeanScore float64
+}
+
+// UCB1 returns the arm's upper confidence bound given the pool's total pull
+// count. An arm that has never been pulled always wins ties by returning +Inf
+// so every arm gets pulled at least once before exploitation kicks in.
+func (a *BanditArm) UCB1(totalPulls int) float64 {
+	if a.Pulls == 0 {
+		return math.Inf(1)
+	}
+	return a.MeanScore + math.Sqrt(2*math.Log(float64(totalPulls))/float64(a.Pulls))
+}
+
+// LowerConfidenceBound mirrors UCB1 but subtracts the exploration term,
+// giving a conservative estimate of the arm's true score. PromptOptimizer
+// stops the bandit loop once the best arm's lower confidence bound crosses
+// its configured threshold.
+func (a *BanditArm) LowerConfidenceBound(totalPulls int) float64 {
+	if a.Pulls == 0 {
+		return math.Inf(-1)
+	}
+	return a.MeanScore - math.Sqrt(2*math.Log(float64(totalPulls))/float64(a.Pulls))
+}
+
+// BanditPool is a bounded set of candidate prompts tracked via UCB1. Every
+// MutationInterval iterations, the pool spawns a mutated arm from the
+// current best and evicts the worst, keeping the pool size bounded.
+type BanditPool struct {
+	Arms             []*BanditArm
+	MaxSize          int
+	MutationInterval int
+
+	nextArmID int
+}
+
+// NewBanditPool seeds a pool from the initial prompt plus any additional
+// mutated variants, bounded to maxSize arms.
+func NewBanditPool(seedPrompt string, variants []string, maxSize, mutationInterval int) *BanditPool {
+	pool := &BanditPool{MaxSize: maxSize, MutationInterval: mutationInterval}
+	pool.addArm(seedPrompt)
+	for _, v := range variants {
+		if len(pool.Arms) >= maxSize {
+			break
+		}
+		pool.addArm(v)
+	}
+	return pool
+}
+
+func (p *BanditPool) addArm(prompt string) *BanditArm {
+	p.nextArmID++
+	arm := &BanditArm{ID: fmt.Sprintf("arm-%d", p.nextArmID), Prompt: prompt}
+	p.Arms = append(p.Arms, arm)
+	return arm
+}
+
+// totalPulls sums pulls across every arm, the T term in UCB1's exploration
+// bonus.
+func (p *BanditPool) totalPulls() int {
+	total := 0
+	for _, arm := range p.Arms {
+		total += arm.Pulls
+	}
+	return total
+}
+
+// TotalPulls exposes totalPulls to callers outside the package (e.g. to
+// evaluate an arm's LowerConfidenceBound against the pool's current total).
+func (p *BanditPool) TotalPulls() int {
+	return p.totalPulls()
+}
+
+// Len returns the number of arms currently in the pool.
+func (p *BanditPool) Len() int {
+	return len(p.Arms)
+}
+
+// Select returns the arm with the highest UCB1 score, the one the next
+// iteration should pull.
+func (p *BanditPool) Select() *BanditArm {
+	total := p.totalPulls() + 1 // avoid ln(0) before the first pull
+	best := p.Arms[0]
+	bestScore := best.UCB1(total)
+	for _, arm := range p.Arms[1:] {
+		if score := arm.UCB1(total); score > bestScore {
+			best, bestScore = arm, score
+		}
+	}
+	return best
+}
+
+// Update folds a new assessment score in [0,1] into arm's running mean.
+func (p *BanditPool) Update(arm *BanditArm, score float64) {
+	arm.MeanScore = (arm.MeanScore*float64(arm.Pulls) + score) / float64(arm.Pulls+1)
+	arm.Pulls++
+}
+
+// Best returns the arm with the highest mean score.
+func (p *BanditPool) Best() *BanditArm {
+	best := p.Arms[0]
+	for _, arm := range p.Arms[1:] {
+		if arm.MeanScore > best.MeanScore {
+			best = arm
+		}
+	}
+	return best
+}
+
+// Evolve spawns a mutated arm from the current best (via mutate) and evicts
+// the worst-performing arm once the pool is at MaxSize, keeping pool size
+// bounded as new candidates are introduced.
+func (p *BanditPool) Evolve(mutate func(best string) string) {
+	mutated := mutate(p.Best().Prompt)
+	newArm := p.addArm(mutated)
+
+	if len(p.Arms) <= p.MaxSize {
+		return
+	}
+
+	sort.Slice(p.Arms, func(i, j int) bool { return p.Arms[i].MeanScore < p.Arms[j].MeanScore })
+	for i, arm := range p.Arms {
+		if arm == newArm {
+			continue
+		}
+		p.Arms = append(p.Arms[:i], p.Arms[i+1:]...)
+		break
+	}
+}
@@ -0,0 +1,95 @@
+// File: internal/llm/bandit_test.go
+
+package llm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBanditArmUCB1UntouchedArmWins(t *testing.T) {
+	arm := &BanditArm{ID: "a", Pulls: 0}
+	if got := arm.UCB1(10); !math.IsInf(got, 1) {
+		t.Errorf("UCB1 for an unpulled arm = %v, want +Inf", got)
+	}
+}
+
+func TestBanditArmUCB1ExplorationBonusShrinksWithPulls(t *testing.T) {
+	arm := &BanditArm{MeanScore: 0.5, Pulls: 1}
+	early := arm.UCB1(10)
+	arm.Pulls = 100
+	late := arm.UCB1(1000)
+	if late >= early {
+		t.Errorf("UCB1 exploration bonus should shrink as pulls grow: early=%v late=%v", early, late)
+	}
+}
+
+func TestBanditArmLowerConfidenceBoundUntouchedArmLoses(t *testing.T) {
+	arm := &BanditArm{ID: "a", Pulls: 0}
+	if got := arm.LowerConfidenceBound(10); !math.IsInf(got, -1) {
+		t.Errorf("LowerConfidenceBound for an unpulled arm = %v, want -Inf", got)
+	}
+}
+
+func TestBanditPoolSelectPrefersUnpulledArms(t *testing.T) {
+	pool := NewBanditPool("seed", []string{"variant-a", "variant-b"}, 3, 5)
+	pool.Update(pool.Arms[0], 0.9) // pull the seed arm once; others remain untouched
+
+	selected := pool.Select()
+	if selected == pool.Arms[0] {
+		t.Errorf("Select should prefer an untouched arm over the already-pulled one")
+	}
+}
+
+func TestBanditPoolUpdateTracksRunningMean(t *testing.T) {
+	pool := NewBanditPool("seed", nil, 1, 5)
+	arm := pool.Arms[0]
+
+	pool.Update(arm, 0.5)
+	pool.Update(arm, 1.0)
+
+	if arm.Pulls != 2 {
+		t.Errorf("Pulls = %d, want 2", arm.Pulls)
+	}
+	if want := 0.75; math.Abs(arm.MeanScore-want) > 1e-9 {
+		t.Errorf("MeanScore = %v, want %v", arm.MeanScore, want)
+	}
+}
+
+func TestBanditPoolBestReturnsHighestMean(t *testing.T) {
+	pool := NewBanditPool("seed", []string{"variant-a"}, 2, 5)
+	pool.Update(pool.Arms[0], 0.2)
+	pool.Update(pool.Arms[1], 0.8)
+
+	if best := pool.Best(); best != pool.Arms[1] {
+		t.Errorf("Best() = %s, want the arm with the higher mean score", best.ID)
+	}
+}
+
+func TestBanditPoolEvolveEvictsWorstOnceAtMaxSize(t *testing.T) {
+	pool := NewBanditPool("seed", []string{"variant-a"}, 2, 1)
+	pool.Update(pool.Arms[0], 0.1) // worst
+	pool.Update(pool.Arms[1], 0.9) // best
+
+	pool.Evolve(func(best string) string { return best + "-mutated" })
+
+	if pool.Len() != 2 {
+		t.Fatalf("pool size after Evolve = %d, want MaxSize (2)", pool.Len())
+	}
+	for _, arm := range pool.Arms {
+		if arm.MeanScore == 0.1 {
+			t.Errorf("Evolve should have evicted the worst-performing arm, found it still in the pool")
+		}
+	}
+}
+
+func TestBanditPoolTotalPulls(t *testing.T) {
+	pool := NewBanditPool("seed", []string{"variant-a"}, 2, 5)
+	pool.Update(pool.Arms[0], 0.5)
+	pool.Update(pool.Arms[1], 0.5)
+	pool.Update(pool.Arms[1], 0.5)
+
+	if got := pool.TotalPulls(); got != 3 {
+		t.Errorf("TotalPulls() = %d, want 3", got)
+	}
+}
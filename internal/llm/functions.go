@@ -0,0 +1,86 @@
+// File: internal/llm/functions.go
+
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// FunctionDef describes a single callable function/tool that an LLM can be
+// asked to invoke instead of answering in free text.
+type FunctionDef struct {
+	Name        string
+	Description string
+	Parameters  *jsonschema.Schema
+}
+
+// FunctionsToTools converts FunctionDefs into the OpenAI-style "tools" array
+// understood by providers such as OpenAI, ZhiPu, and TongYi.
+func FunctionsToTools(fns []FunctionDef) []map[string]interface{} {
+	tools := make([]map[string]interface{}, 0, len(fns))
+	for _, fn := range fns {
+		tools = append(tools, map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        fn.Name,
+				"description": fn.Description,
+				"parameters":  fn.Parameters,
+			},
+		})
+	}
+	return tools
+}
+
+// ParseFunctionCall extracts a function name and its arguments from a
+// provider response. It accepts both the OpenAI tool-call shape
+// (`{"name": "...", "arguments": {...}}`) and a bare "parameters" key, so it
+// works regardless of which provider produced the response.
+func ParseFunctionCall(response string) (name string, args json.RawMessage, err error) {
+	response = ExtractJSONObject(response)
+	if !strings.HasPrefix(response, "{") {
+		return "", nil, fmt.Errorf("no function call found in response")
+	}
+
+	var call struct {
+		Name       string          `json:"name"`
+		Arguments  json.RawMessage `json:"arguments"`
+		Parameters json.RawMessage `json:"parameters"`
+	}
+	if err := json.Unmarshal([]byte(response), &call); err != nil {
+		return "", nil, fmt.Errorf("error parsing function call: %w", err)
+	}
+	if call.Name == "" {
+		return "", nil, fmt.Errorf("function call response missing name")
+	}
+
+	args = call.Arguments
+	if len(args) == 0 {
+		args = call.Parameters
+	}
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+
+	return call.Name, args, nil
+}
+
+// ExtractJSONObject trims markdown code fences and surrounding prose,
+// returning the first top-level JSON object in response. CleanResponse
+// (package gollm) and ParseFunctionCall both call this so the extraction
+// rules live in one place instead of drifting apart.
+func ExtractJSONObject(response string) string {
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimSuffix(response, "```")
+
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start != -1 && end != -1 && end > start {
+		response = response[start : end+1]
+	}
+
+	return strings.TrimSpace(response)
+}
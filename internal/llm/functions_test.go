@@ -0,0 +1,51 @@
+// File: internal/llm/functions_test.go
+
+package llm
+
+import "testing"
+
+func TestExtractJSONObject(t *testing.T) {
+	cases := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{"plain", `{"a":1}`, `{"a":1}`},
+		{"fenced", "```json\n{\"a\":1}\n```", `{"a":1}`},
+		{"surrounding prose", `here you go: {"a":1} thanks`, `{"a":1}`},
+		{"no braces", "no json here", "no json here"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ExtractJSONObject(c.response); got != c.want {
+				t.Errorf("ExtractJSONObject(%q) = %q, want %q", c.response, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseFunctionCall(t *testing.T) {
+	name, args, err := ParseFunctionCall(`{"name": "get_weather", "arguments": {"city": "nyc"}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "get_weather" {
+		t.Errorf("name = %q, want get_weather", name)
+	}
+	if string(args) != `{"city": "nyc"}` {
+		t.Errorf("args = %s, want {\"city\": \"nyc\"}", args)
+	}
+}
+
+func TestParseFunctionCallNoCall(t *testing.T) {
+	if _, _, err := ParseFunctionCall("just a plain text response"); err == nil {
+		t.Error("expected an error when no function call is present")
+	}
+}
+
+func TestParseFunctionCallMissingName(t *testing.T) {
+	if _, _, err := ParseFunctionCall(`{"arguments": {}}`); err == nil {
+		t.Error("expected an error when the response has no function name")
+	}
+}
@@ -0,0 +1,182 @@
+// File: internal/llm/grammar.go
+
+package llm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// SchemaToGrammar renders a JSON schema as a BNF-like grammar string that can
+// be passed through as a "grammar" option to providers that constrain
+// decoding (e.g. llama.cpp-style GBNF consumers). $ref entries are resolved
+// against the schema's own $defs.
+func SchemaToGrammar(schema *jsonschema.Schema) string {
+	b := &grammarBuilder{
+		defs:  schema.Definitions,
+		rules: map[string]string{},
+	}
+	root := b.define("root", schema)
+	return renderGrammar(b, b.rules[root])
+}
+
+// FunctionsToGrammar renders a grammar that alternates over every function's
+// parameter schema, so offering WithFunctions more than one FunctionDef
+// constrains decoding to any of their object shapes instead of collapsing
+// onto the first one. Functions without a Parameters schema are skipped;
+// it returns "" if none of fns have one.
+func FunctionsToGrammar(fns []FunctionDef) string {
+	b := &grammarBuilder{rules: map[string]string{}}
+
+	var roots []string
+	for i, fn := range fns {
+		if fn.Parameters == nil {
+			continue
+		}
+		b.defs = fn.Parameters.Definitions
+		roots = append(roots, b.define(fmt.Sprintf("fn%d", i), fn.Parameters))
+	}
+	if len(roots) == 0 {
+		return ""
+	}
+
+	return renderGrammar(b, strings.Join(roots, " | "))
+}
+
+// renderGrammar writes out b's accumulated rules as "name ::= production"
+// lines, with a synthesized "root ::= rootExpr" line leading regardless of
+// how b.rules sorts.
+func renderGrammar(b *grammarBuilder, rootExpr string) string {
+	names := make([]string, 0, len(b.rules))
+	for name := range b.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "root ::= %s\n", rootExpr)
+	for _, name := range names {
+		if name == "root" {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s ::= %s\n", name, b.rules[name])
+	}
+	return sb.String()
+}
+
+// grammarBuilder walks a schema, emitting one named production per property,
+// array item, and resolved $ref definition it encounters, whatever its type,
+// so $ref cycles terminate naturally.
+type grammarBuilder struct {
+	defs  jsonschema.Definitions
+	rules map[string]string
+}
+
+func (b *grammarBuilder) define(name string, schema *jsonschema.Schema) string {
+	if _, ok := b.rules[name]; ok {
+		return name
+	}
+	b.rules[name] = "" // reserve the name before recursing, for cycles
+	b.rules[name] = b.production(name, schema)
+	return name
+}
+
+func (b *grammarBuilder) production(name string, schema *jsonschema.Schema) string {
+	if schema == nil {
+		return "value"
+	}
+
+	if schema.Ref != "" {
+		return b.resolveRef(schema.Ref)
+	}
+
+	if len(schema.Enum) > 0 {
+		alts := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			alts = append(alts, fmt.Sprintf("%q", fmt.Sprintf("%v", v)))
+		}
+		return strings.Join(alts, " | ")
+	}
+
+	switch schema.Type {
+	case "object":
+		return b.object(name, schema)
+	case "array":
+		item := "value"
+		if schema.Items != nil {
+			item = b.define(name+"-item", schema.Items)
+		}
+		return fmt.Sprintf(`"[" (%s ("," %s)*)? "]"`, item, item)
+	case "string":
+		return `"\"" [^"]* "\""`
+	case "number", "integer":
+		return `["-"]? [0-9]+ ("." [0-9]+)?`
+	case "boolean":
+		return `"true" | "false"`
+	default:
+		return "value"
+	}
+}
+
+func (b *grammarBuilder) object(name string, schema *jsonschema.Schema) string {
+	if schema.Properties == nil || schema.Properties.Len() == 0 {
+		return `"{" "}"`
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+
+	// Required members are always present, so they can be comma-separated
+	// normally. Optional members are rendered after them, each carrying its
+	// own leading comma inside its "(...)?" wrapper: that way, whichever
+	// optional members are actually omitted, the comma goes with them
+	// instead of being left dangling next to whichever neighbor remains.
+	var reqMembers, optMembers []string
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		propName, propSchema := pair.Key, pair.Value
+		ruleName := b.define(fmt.Sprintf("%s-%s", name, propName), propSchema)
+		member := fmt.Sprintf(`"\"%s\"" ":" %s`, propName, ruleName)
+		if required[propName] {
+			reqMembers = append(reqMembers, member)
+		} else {
+			optMembers = append(optMembers, member)
+		}
+	}
+
+	production := strings.Join(reqMembers, ` "," `)
+	if production == "" {
+		// With no required member to follow, whichever optional member comes
+		// first carries no comma: one alternative per possible first member,
+		// each followed by the later members with their own leading commas.
+		alts := make([]string, 0, len(optMembers))
+		for i, first := range optMembers {
+			alt := first
+			for _, member := range optMembers[i+1:] {
+				alt += fmt.Sprintf(` ("," %s)?`, member)
+			}
+			alts = append(alts, alt)
+		}
+		return fmt.Sprintf(`"{" (%s)? "}"`, strings.Join(alts, " | "))
+	}
+	for _, member := range optMembers {
+		production += fmt.Sprintf(` ("," %s)?`, member)
+	}
+
+	return fmt.Sprintf(`"{" %s "}"`, production)
+}
+
+func (b *grammarBuilder) resolveRef(ref string) string {
+	name := ref
+	if idx := strings.LastIndex(ref, "/"); idx != -1 {
+		name = ref[idx+1:]
+	}
+	if def, ok := b.defs[name]; ok {
+		return b.define(name, def)
+	}
+	return "value"
+}
@@ -0,0 +1,120 @@
+// File: internal/llm/grammar_test.go
+
+package llm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+func TestSchemaToGrammarPrimitives(t *testing.T) {
+	props := orderedmap.New[string, *jsonschema.Schema]()
+	props.Set("name", &jsonschema.Schema{Type: "string"})
+	props.Set("age", &jsonschema.Schema{Type: "integer"})
+
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: props,
+		Required:   []string{"name"},
+	}
+
+	grammar := SchemaToGrammar(schema)
+
+	if !strings.HasPrefix(grammar, "root ::=") {
+		t.Errorf("grammar should start with the root production, got: %s", grammar)
+	}
+	if !strings.Contains(grammar, `"\"name\""`) || !strings.Contains(grammar, `"\"age\""`) {
+		t.Errorf("grammar should reference both properties, got: %s", grammar)
+	}
+	if !strings.Contains(grammar, "root-age)?") {
+		t.Errorf("optional age property should be wrapped as optional, got: %s", grammar)
+	}
+}
+
+func TestSchemaToGrammarEnum(t *testing.T) {
+	schema := &jsonschema.Schema{Enum: []interface{}{"red", "green", "blue"}}
+	grammar := SchemaToGrammar(schema)
+
+	for _, want := range []string{"red", "green", "blue"} {
+		if !strings.Contains(grammar, want) {
+			t.Errorf("grammar missing enum value %q, got: %s", want, grammar)
+		}
+	}
+}
+
+func TestFunctionsToGrammarAlternatesAllFunctions(t *testing.T) {
+	getWeather := &jsonschema.Schema{Type: "object"}
+	props := orderedmap.New[string, *jsonschema.Schema]()
+	props.Set("city", &jsonschema.Schema{Type: "string"})
+	getWeather.Properties = props
+	getWeather.Required = []string{"city"}
+
+	sendEmail := &jsonschema.Schema{Type: "object"}
+	props2 := orderedmap.New[string, *jsonschema.Schema]()
+	props2.Set("to", &jsonschema.Schema{Type: "string"})
+	sendEmail.Properties = props2
+	sendEmail.Required = []string{"to"}
+
+	grammar := FunctionsToGrammar([]FunctionDef{
+		{Name: "get_weather", Parameters: getWeather},
+		{Name: "send_email", Parameters: sendEmail},
+	})
+
+	if !strings.HasPrefix(grammar, "root ::=") {
+		t.Fatalf("grammar should start with the root production, got: %s", grammar)
+	}
+	if !strings.Contains(grammar, "fn0 | fn1") {
+		t.Errorf("root should alternate over both functions' schemas, got: %s", grammar)
+	}
+	if !strings.Contains(grammar, `"\"city\""`) || !strings.Contains(grammar, `"\"to\""`) {
+		t.Errorf("grammar should reference both functions' properties, got: %s", grammar)
+	}
+}
+
+func TestFunctionsToGrammarNoParameters(t *testing.T) {
+	grammar := FunctionsToGrammar([]FunctionDef{{Name: "no_args"}})
+	if grammar != "" {
+		t.Errorf("expected empty grammar when no function has Parameters, got: %s", grammar)
+	}
+}
+
+func TestSchemaToGrammarOptionalMemberOwnsItsComma(t *testing.T) {
+	props := orderedmap.New[string, *jsonschema.Schema]()
+	props.Set("nickname", &jsonschema.Schema{Type: "string"})
+	props.Set("name", &jsonschema.Schema{Type: "string"})
+
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: props,
+		Required:   []string{"name"},
+	}
+
+	grammar := SchemaToGrammar(schema)
+
+	if !strings.Contains(grammar, `("," "\"nickname\""`) {
+		t.Errorf("leading comma before an optional member should be folded into its own (...)? wrapper, got: %s", grammar)
+	}
+	if strings.Contains(grammar, `"{" ","`) {
+		t.Errorf("omitting a leading optional member must not leave a dangling comma right after \"{\", got: %s", grammar)
+	}
+}
+
+func TestSchemaToGrammarRef(t *testing.T) {
+	addr := &jsonschema.Schema{Type: "string"}
+	schema := &jsonschema.Schema{
+		Type:        "object",
+		Definitions: jsonschema.Definitions{"Address": addr},
+	}
+	props := orderedmap.New[string, *jsonschema.Schema]()
+	props.Set("address", &jsonschema.Schema{Ref: "#/$defs/Address"})
+	schema.Properties = props
+	schema.Required = []string{"address"}
+
+	grammar := SchemaToGrammar(schema)
+	if !strings.Contains(grammar, "Address ::=") {
+		t.Errorf("grammar should emit a production for the resolved $ref, got: %s", grammar)
+	}
+}
@@ -0,0 +1,92 @@
+// File: internal/llm/grpc/client.go
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+const serviceName = "gollm.external.ExternalBackend"
+
+// ExternalBackendClient is a thin client for the ExternalBackend service
+// described in external.proto, talking the "json" content-subtype registered
+// in codec.go.
+type ExternalBackendClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewExternalBackendClient wraps an already-dialed connection.
+func NewExternalBackendClient(conn *grpc.ClientConn) *ExternalBackendClient {
+	return &ExternalBackendClient{conn: conn}
+}
+
+func callOpts() []grpc.CallOption {
+	return []grpc.CallOption{grpc.CallContentSubtype("json")}
+}
+
+// Health reports whether the backend is ready to serve requests.
+func (c *ExternalBackendClient) Health(ctx context.Context, req *HealthRequest) (*HealthReply, error) {
+	reply := new(HealthReply)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/Health", req, reply, callOpts()...); err != nil {
+		return nil, fmt.Errorf("health rpc failed: %w", err)
+	}
+	return reply, nil
+}
+
+// Embedding returns a vector embedding for the given input.
+func (c *ExternalBackendClient) Embedding(ctx context.Context, req *EmbeddingRequest) (*EmbeddingReply, error) {
+	reply := new(EmbeddingReply)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/Embedding", req, reply, callOpts()...); err != nil {
+		return nil, fmt.Errorf("embedding rpc failed: %w", err)
+	}
+	return reply, nil
+}
+
+// ImageGeneration produces one or more images for the given prompt.
+func (c *ExternalBackendClient) ImageGeneration(ctx context.Context, req *ImageGenerationRequest) (*ImageGenerationReply, error) {
+	reply := new(ImageGenerationReply)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/ImageGeneration", req, reply, callOpts()...); err != nil {
+		return nil, fmt.Errorf("image generation rpc failed: %w", err)
+	}
+	return reply, nil
+}
+
+// Predict opens a server-streaming call and relays each token reply onto the
+// returned channel, closing it when the stream ends, the backend sends its
+// terminal Done reply, or ctx is cancelled.
+func (c *ExternalBackendClient) Predict(ctx context.Context, req *PredictRequest) (<-chan *TokenReply, error) {
+	desc := &grpc.StreamDesc{ServerStreams: true}
+	stream, err := c.conn.NewStream(ctx, desc, "/"+serviceName+"/Predict", callOpts()...)
+	if err != nil {
+		return nil, fmt.Errorf("predict rpc failed: %w", err)
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, fmt.Errorf("predict rpc send failed: %w", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("predict rpc close send failed: %w", err)
+	}
+
+	replies := make(chan *TokenReply)
+	go func() {
+		defer close(replies)
+		for {
+			reply := new(TokenReply)
+			if err := stream.RecvMsg(reply); err != nil {
+				return
+			}
+			select {
+			case replies <- reply:
+			case <-ctx.Done():
+				return
+			}
+			if reply.Done {
+				return
+			}
+		}
+	}()
+	return replies, nil
+}
@@ -0,0 +1,30 @@
+// File: internal/llm/grpc/codec.go
+
+package grpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec implements encoding.Codec using encoding/json. It's registered
+// under the "json" content-subtype so ExternalBackendClient can exchange the
+// plain structs in types.go without requiring protoc-generated messages.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
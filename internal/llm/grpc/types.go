@@ -0,0 +1,54 @@
+// File: internal/llm/grpc/types.go
+
+// Package grpc defines the wire contract for driving an out-of-process model
+// backend over gRPC (see external.proto) and a hand-maintained client for it.
+// The message types below mirror external.proto field-for-field; they're
+// marshaled with the "json" codec registered in codec.go rather than the
+// protobuf wire format, since no protoc-generated stubs are checked in yet.
+package grpc
+
+// PredictRequest carries a single completion request to the external
+// backend. Options mirrors Provider.PrepareRequest's option map, with each
+// value rendered as a string since gRPC's map value type can't be
+// interface{}.
+type PredictRequest struct {
+	Prompt  string            `json:"prompt"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// TokenReply is one item of the token stream Predict returns. Done marks the
+// final reply, after which Token is always empty.
+type TokenReply struct {
+	Token string `json:"token"`
+	Done  bool   `json:"done"`
+}
+
+// EmbeddingRequest asks the backend to embed a single input string.
+type EmbeddingRequest struct {
+	Input   string            `json:"input"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// EmbeddingReply carries the resulting vector.
+type EmbeddingReply struct {
+	Embedding []float32 `json:"embedding"`
+}
+
+// ImageGenerationRequest asks the backend to generate one or more images.
+type ImageGenerationRequest struct {
+	Prompt  string            `json:"prompt"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// ImageGenerationReply carries the resulting image URLs.
+type ImageGenerationReply struct {
+	Urls []string `json:"urls"`
+}
+
+// HealthRequest takes no parameters.
+type HealthRequest struct{}
+
+// HealthReply reports whether the backend is ready to serve requests.
+type HealthReply struct {
+	Ready bool `json:"ready"`
+}
@@ -0,0 +1,133 @@
+// File: internal/llm/grpc_external.go
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	grpcstubs "gollm/internal/llm/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func RegisterGRPCExternalProvider(registry *ProviderRegistry) {
+	registry.Register("grpc_external", NewGRPCProvider)
+}
+
+// GRPCProvider lets gollm drive an out-of-process model backend (llama.cpp,
+// vLLM, a custom Python server, ...) over gRPC instead of baking it into the
+// repo, modeled after LocalAI's external-backend design. See
+// internal/llm/grpc/external.proto for the wire contract.
+type GRPCProvider struct {
+	model  string
+	addr   string
+	conn   *grpc.ClientConn
+	client *grpcstubs.ExternalBackendClient
+}
+
+func NewGRPCProvider(apiKey, model string) Provider {
+	return &GRPCProvider{model: model}
+}
+
+func (p *GRPCProvider) Name() string {
+	return "grpc_external"
+}
+
+func (p *GRPCProvider) Endpoint() string {
+	return p.addr
+}
+
+func (p *GRPCProvider) Headers() map[string]string {
+	return nil
+}
+
+// SetGRPCEndpoint dials the external backend at addr, replacing any existing
+// connection.
+func (p *GRPCProvider) SetGRPCEndpoint(addr string) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("error dialing grpc external backend: %w", err)
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.addr = addr
+	p.conn = conn
+	p.client = grpcstubs.NewExternalBackendClient(conn)
+	return nil
+}
+
+func optionsToStrings(options map[string]interface{}) map[string]string {
+	out := make(map[string]string, len(options))
+	for k, v := range options {
+		out[k] = fmt.Sprintf("%v", v)
+	}
+	return out
+}
+
+// PrepareRequest renders prompt and options into a PredictRequest so
+// GRPCProvider can be driven through the same Provider surface as the HTTP
+// providers, even though the actual call goes out over the gRPC connection
+// rather than an HTTP body.
+func (p *GRPCProvider) PrepareRequest(prompt string, options map[string]interface{}) ([]byte, error) {
+	req := &grpcstubs.PredictRequest{Prompt: prompt, Options: optionsToStrings(options)}
+	return json.Marshal(req)
+}
+
+func (p *GRPCProvider) ParseResponse(body []byte) (string, error) {
+	var reply grpcstubs.TokenReply
+	if err := json.Unmarshal(body, &reply); err != nil {
+		return "", fmt.Errorf("error parsing response: %w", err)
+	}
+	return reply.Token, nil
+}
+
+// GenerateGRPC runs a single completion against the external backend,
+// blocking until the full response has been assembled from the streamed
+// tokens. Named GenerateGRPC, not Generate, so it satisfies
+// GRPCGenerateProvider instead of colliding with the internal LLM's own
+// Generate method (see GRPCStreamingProvider's doc comment in stream.go).
+func (p *GRPCProvider) GenerateGRPC(ctx context.Context, prompt string, options map[string]interface{}) (string, error) {
+	if p.client == nil {
+		return "", fmt.Errorf("grpc external backend not configured: call SetGRPCEndpoint first")
+	}
+	tokens, err := p.client.Predict(ctx, &grpcstubs.PredictRequest{Prompt: prompt, Options: optionsToStrings(options)})
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for reply := range tokens {
+		sb.WriteString(reply.Token)
+	}
+	return sb.String(), nil
+}
+
+// StreamGRPC runs Predict against the external backend and relays each token
+// delta over the returned channel, satisfying GRPCStreamingProvider so
+// llmImpl.Stream can reach it even though GRPCProvider talks gRPC rather
+// than Server-Sent Events like StreamingProvider's HTTP-backed implementations.
+func (p *GRPCProvider) StreamGRPC(ctx context.Context, prompt string, options map[string]interface{}) (<-chan StreamChunk, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("grpc external backend not configured: call SetGRPCEndpoint first")
+	}
+	tokens, err := p.client.Predict(ctx, &grpcstubs.PredictRequest{Prompt: prompt, Options: optionsToStrings(options)})
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan StreamChunk)
+	go func() {
+		defer close(chunks)
+		for reply := range tokens {
+			if reply.Done {
+				chunks <- StreamChunk{Done: true}
+				return
+			}
+			chunks <- StreamChunk{Content: reply.Token}
+		}
+	}()
+	return chunks, nil
+}
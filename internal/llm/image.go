@@ -0,0 +1,132 @@
+// File: internal/llm/image.go
+
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrUnsupported is returned by optional-capability methods (such as
+// GenerateImage) when the configured provider doesn't implement them, so
+// callers can feature-detect instead of failing opaquely.
+var ErrUnsupported = errors.New("operation not supported by this provider")
+
+// ContentFilterResult mirrors a single entry of zhipu_view's "content_filter"
+// array, which today gets silently discarded by ParseResponse.
+type ContentFilterResult struct {
+	Role  string `json:"role"`
+	Level int32  `json:"level"`
+}
+
+// ImageResult is a single generated image, covering both URL- and
+// base64-returning providers.
+type ImageResult struct {
+	URL           string
+	B64JSON       string
+	RevisedPrompt string
+	ContentFilter []ContentFilterResult
+}
+
+// ImageOptions configures an image-generation request. Zero values are
+// omitted from the request body so provider defaults apply.
+type ImageOptions struct {
+	Size           string
+	N              int
+	Quality        string
+	Style          string
+	ResponseFormat string
+}
+
+func (o ImageOptions) toMap() map[string]interface{} {
+	m := map[string]interface{}{}
+	if o.Size != "" {
+		m["size"] = o.Size
+	}
+	if o.N > 0 {
+		m["n"] = o.N
+	}
+	if o.Quality != "" {
+		m["quality"] = o.Quality
+	}
+	if o.Style != "" {
+		m["style"] = o.Style
+	}
+	if o.ResponseFormat != "" {
+		m["response_format"] = o.ResponseFormat
+	}
+	return m
+}
+
+// ImageProvider is an optional capability a Provider can implement to
+// support image generation. It's kept separate from Provider because an
+// image result carries more than the single string Generate returns (a
+// URL/base64 payload, a revised prompt, content-filter metadata), and because
+// most providers (cogview-3, DALL-E, ...) speak a different endpoint than
+// their text completion counterpart.
+type ImageProvider interface {
+	GenerateImage(ctx context.Context, prompt string, opts ImageOptions) ([]ImageResult, error)
+}
+
+// imageHTTPRequest posts body to endpoint with headers and returns the raw
+// response, used by ImageProvider implementations that, like the text
+// providers in this package, talk a plain JSON-over-HTTP API.
+func imageHTTPRequest(ctx context.Context, endpoint string, headers map[string]string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating image request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending image request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("error reading image response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image request failed with status %d: %s", resp.StatusCode, buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeImageResponse parses the OpenAI-compatible images.generations
+// response shape shared by cogview-3 and DALL-E-style providers.
+func decodeImageResponse(body []byte) ([]ImageResult, error) {
+	var response struct {
+		Data []struct {
+			Url           string `json:"url"`
+			B64JSON       string `json:"b64_json"`
+			RevisedPrompt string `json:"revised_prompt"`
+		} `json:"data"`
+		ContentFilter []ContentFilterResult `json:"content_filter"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error parsing image response: %w", err)
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("empty image response from API")
+	}
+
+	results := make([]ImageResult, 0, len(response.Data))
+	for _, d := range response.Data {
+		results = append(results, ImageResult{
+			URL:           d.Url,
+			B64JSON:       d.B64JSON,
+			RevisedPrompt: d.RevisedPrompt,
+			ContentFilter: response.ContentFilter,
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,103 @@
+// File: internal/llm/openai_compatible.go
+
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func RegisterOpenAICompatibleProvider(registry *ProviderRegistry) {
+	registry.Register("openai_compatible", NewOpenAICompatibleProvider)
+}
+
+// RegisterOpenAICompatibleEndpoint registers name as an openai_compatible
+// provider pre-bound to endpoint. This lets a gallery manifest describe
+// several distinct self-hosted backends, each selectable by its own name, in
+// addition to the single shared "openai_compatible" provider configured via
+// SetEndpoint after construction.
+func RegisterOpenAICompatibleEndpoint(registry *ProviderRegistry, name, endpoint string) {
+	registry.Register(name, func(apiKey, model string) Provider {
+		provider := NewOpenAICompatibleProvider(apiKey, model).(*OpenAICompatibleProvider)
+		provider.SetEndpoint(endpoint)
+		return provider
+	})
+}
+
+// OpenAICompatibleProvider talks to any user-supplied base URL that speaks
+// the OpenAI chat-completions wire format (llama.cpp, vLLM, Ollama's
+// compatible-mode endpoints, ...), so a gallery manifest can describe
+// arbitrary self-hosted endpoints without a dedicated provider file for
+// each one.
+type OpenAICompatibleProvider struct {
+	apiKey   string
+	model    string
+	endpoint string
+}
+
+func NewOpenAICompatibleProvider(apiKey, model string) Provider {
+	return &OpenAICompatibleProvider{
+		apiKey: apiKey,
+		model:  model,
+	}
+}
+
+func (p *OpenAICompatibleProvider) Name() string {
+	return "openai_compatible"
+}
+
+func (p *OpenAICompatibleProvider) Endpoint() string {
+	return p.endpoint
+}
+
+// SetEndpoint points the provider at a user-supplied base URL. It is the
+// optional SetEndpoint capability that gollm's SetOllamaEndpoint looks for.
+func (p *OpenAICompatibleProvider) SetEndpoint(endpoint string) {
+	p.endpoint = endpoint
+}
+
+func (p *OpenAICompatibleProvider) Headers() map[string]string {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+	return headers
+}
+
+func (p *OpenAICompatibleProvider) PrepareRequest(prompt string, options map[string]interface{}) ([]byte, error) {
+	requestBody := map[string]interface{}{
+		"model": p.model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+
+	for k, v := range options {
+		requestBody[k] = v
+	}
+
+	return json.Marshal(requestBody)
+}
+
+func (p *OpenAICompatibleProvider) ParseResponse(body []byte) (string, error) {
+	var response struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+
+	err := json.Unmarshal(body, &response)
+	if err != nil {
+		return "", fmt.Errorf("error parsing response: %w", err)
+	}
+
+	if len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("empty response from API")
+	}
+
+	return response.Choices[0].Message.Content, nil
+}
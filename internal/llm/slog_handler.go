@@ -0,0 +1,118 @@
+// File: internal/llm/slog_handler.go
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DedupHandler wraps another slog.Handler and suppresses repeat records
+// within a configurable window, so noisy retry/rate-limit logs during
+// PromptOptimizer iterations don't flood output. Records are deduplicated by
+// a key built from their level, message, and attributes; once a record repeats
+// within the window it's counted rather than re-emitted, and a single
+// "repeated N times" record is flushed when the window for that key elapses.
+type DedupHandler struct {
+	next   slog.Handler
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+	record slog.Record
+	count  int
+	first  time.Time
+}
+
+// NewDedupHandler wraps next, suppressing repeats of the same level, message
+// and attributes within window. A window of zero disables deduplication entirely.
+func NewDedupHandler(next slog.Handler, window time.Duration) *DedupHandler {
+	return &DedupHandler{
+		next:   next,
+		window: window,
+		seen:   make(map[string]*dedupEntry),
+	}
+}
+
+func (h *DedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *DedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &DedupHandler{next: h.next.WithAttrs(attrs), window: h.window, seen: make(map[string]*dedupEntry)}
+}
+
+func (h *DedupHandler) WithGroup(name string) slog.Handler {
+	return &DedupHandler{next: h.next.WithGroup(name), window: h.window, seen: make(map[string]*dedupEntry)}
+}
+
+func (h *DedupHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.window <= 0 {
+		return h.next.Handle(ctx, record)
+	}
+
+	key := dedupKey(record)
+	now := time.Now()
+
+	h.mu.Lock()
+	entry, ok := h.seen[key]
+	if !ok || now.Sub(entry.first) > h.window {
+		h.seen[key] = &dedupEntry{record: record, count: 1, first: now}
+		h.mu.Unlock()
+		// An expired entry Flush hasn't reached yet still gets its summary.
+		if ok && entry.count > 1 {
+			summary := entry.record.Clone()
+			summary.Message = fmt.Sprintf("%s (repeated %d times)", entry.record.Message, entry.count)
+			if err := h.next.Handle(ctx, summary); err != nil {
+				return err
+			}
+		}
+		return h.next.Handle(ctx, record)
+	}
+	entry.count++
+	h.mu.Unlock()
+	return nil
+}
+
+// Flush emits a "repeated N times" summary record for every key whose
+// window has elapsed and that was suppressed at least once, then resets
+// those entries. Callers typically run this on a timer alongside logging.
+func (h *DedupHandler) Flush(ctx context.Context) error {
+	now := time.Now()
+
+	h.mu.Lock()
+	var toFlush []*dedupEntry
+	for key, entry := range h.seen {
+		if now.Sub(entry.first) > h.window {
+			if entry.count > 1 {
+				toFlush = append(toFlush, entry)
+			}
+			delete(h.seen, key)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, entry := range toFlush {
+		summary := entry.record.Clone()
+		summary.Message = fmt.Sprintf("%s (repeated %d times)", entry.record.Message, entry.count)
+		if err := h.next.Handle(ctx, summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dedupKey(record slog.Record) string {
+	key := fmt.Sprintf("%d|%s", record.Level, record.Message)
+	record.Attrs(func(attr slog.Attr) bool {
+		key += fmt.Sprintf("|%s=%v", attr.Key, attr.Value)
+		return true
+	})
+	return key
+}
@@ -0,0 +1,73 @@
+// File: internal/llm/slog_handler_test.go
+
+package llm
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestDedupHandlerSuppressesRepeats(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewTextHandler(&buf, nil)
+	h := NewDedupHandler(inner, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		record := slog.NewRecord(time.Now(), slog.LevelWarn, "rate limited", 0)
+		if err := h.Handle(ctx, record); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+
+	got := buf.String()
+	count := bytes.Count([]byte(got), []byte("rate limited"))
+	if count != 1 {
+		t.Errorf("expected exactly 1 emitted record for 5 repeats within the window, got %d: %s", count, got)
+	}
+}
+
+func TestDedupHandlerFlushEmitsSummary(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewTextHandler(&buf, nil)
+	h := NewDedupHandler(inner, time.Millisecond)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		record := slog.NewRecord(time.Now(), slog.LevelWarn, "rate limited", 0)
+		if err := h.Handle(ctx, record); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if err := h.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("repeated 3 times")) {
+		t.Errorf("expected flush to emit a repeated-N-times summary, got: %s", buf.String())
+	}
+}
+
+func TestDedupHandlerZeroWindowDisablesDedup(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewTextHandler(&buf, nil)
+	h := NewDedupHandler(inner, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		record := slog.NewRecord(time.Now(), slog.LevelWarn, "rate limited", 0)
+		if err := h.Handle(ctx, record); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+
+	count := bytes.Count(buf.Bytes(), []byte("rate limited"))
+	if count != 3 {
+		t.Errorf("expected dedup disabled with zero window to emit all 3 records, got %d", count)
+	}
+}
@@ -0,0 +1,128 @@
+// File: internal/llm/stream.go
+
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// StreamChunk represents a single incremental piece of a streamed generation.
+// Content holds the token delta for this chunk; Done is set on the final
+// chunk once the provider has sent its terminating sentinel (e.g. "[DONE]").
+type StreamChunk struct {
+	Content string
+	Done    bool
+	Err     error
+}
+
+// StreamingProvider is an optional capability a Provider can implement to
+// support incremental generation over Server-Sent Events. Providers that
+// don't implement it simply aren't eligible for Stream() and callers fall
+// back to the blocking Generate path.
+type StreamingProvider interface {
+	Provider
+
+	// PrepareStreamRequest behaves like PrepareRequest but marks the request
+	// for streaming (e.g. sets "stream": true in the request body).
+	PrepareStreamRequest(prompt string, options map[string]interface{}) ([]byte, error)
+
+	// ParseStreamChunk extracts the token delta from a single SSE "data:"
+	// payload. done is true once the provider's terminal sentinel is reached,
+	// in which case content is always empty.
+	ParseStreamChunk(data []byte) (content string, done bool, err error)
+}
+
+// GRPCStreamingProvider is a Stream-capable provider whose requests go out
+// over a connection of its own (e.g. gRPC) rather than a single HTTP body,
+// so it takes the raw prompt and options directly instead of going through
+// PrepareStreamRequest/StreamRequest like an SSE-backed StreamingProvider.
+//
+// The capability method is named StreamGRPC rather than Stream: the internal
+// LLM that callers type-assert against (see llmImpl.Stream) already defines
+// its own Stream/Generate of different shapes, and a provider's method of
+// the same name held behind that wrapper is never reachable through it. A
+// distinct name is a dedicated capability method the wrapper can expose on
+// its own terms instead, the same way SetGRPCEndpoint already works.
+type GRPCStreamingProvider interface {
+	Provider
+	StreamGRPC(ctx context.Context, prompt string, options map[string]interface{}) (<-chan StreamChunk, error)
+}
+
+// GRPCGenerateProvider is a blocking-Generate-capable provider reached over a
+// connection of its own (e.g. gRPC) rather than a single HTTP request, so it
+// exposes GenerateGRPC rather than being asserted for directly: see
+// GRPCStreamingProvider's doc comment for why the capability method needs
+// its own name instead of overloading Generate.
+type GRPCGenerateProvider interface {
+	Provider
+	GenerateGRPC(ctx context.Context, prompt string, options map[string]interface{}) (string, error)
+}
+
+// StreamRequest issues a streaming HTTP request against provider and relays
+// each SSE event onto the returned channel. The channel is closed when the
+// stream ends, the context is cancelled, or an unrecoverable error occurs;
+// a non-nil Err on the final chunk distinguishes the latter two.
+func StreamRequest(ctx context.Context, provider StreamingProvider, body []byte) (<-chan StreamChunk, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.Endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating stream request: %w", err)
+	}
+	for k, v := range provider.Headers() {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending stream request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("stream request failed with status %d", resp.StatusCode)
+	}
+
+	chunks := make(chan StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				chunks <- StreamChunk{Err: ctx.Err()}
+				return
+			default:
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+			content, done, err := provider.ParseStreamChunk([]byte(data))
+			if err != nil {
+				chunks <- StreamChunk{Err: err}
+				return
+			}
+			if done {
+				chunks <- StreamChunk{Done: true}
+				return
+			}
+			if content != "" {
+				chunks <- StreamChunk{Content: content}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			chunks <- StreamChunk{Err: fmt.Errorf("error reading stream: %w", err)}
+		}
+	}()
+
+	return chunks, nil
+}
@@ -52,6 +52,52 @@ func (p *TongYiProvider) PrepareRequest(prompt string, options map[string]interf
 	return json.Marshal(requestBody)
 }
 
+// PrepareStreamRequest behaves like PrepareRequest but sets stream: true so
+// the API responds with a Server-Sent Events body instead of a single JSON
+// object.
+func (p *TongYiProvider) PrepareStreamRequest(prompt string, options map[string]interface{}) ([]byte, error) {
+	requestBody := map[string]interface{}{
+		"model": p.model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"stream": true,
+	}
+
+	for k, v := range options {
+		requestBody[k] = v
+	}
+
+	return json.Marshal(requestBody)
+}
+
+// ParseStreamChunk extracts the token delta from a single "data:" payload of
+// the streamed response, recognizing the "[DONE]" sentinel that terminates
+// the stream.
+func (p *TongYiProvider) ParseStreamChunk(data []byte) (string, bool, error) {
+	if string(data) == "[DONE]" {
+		return "", true, nil
+	}
+
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return "", false, fmt.Errorf("error parsing stream chunk: %w", err)
+	}
+
+	if len(chunk.Choices) == 0 {
+		return "", false, nil
+	}
+
+	return chunk.Choices[0].Delta.Content, false, nil
+}
+
 func (p *TongYiProvider) ParseResponse(body []byte) (string, error) {
 	var response struct {
 		Choices []struct {
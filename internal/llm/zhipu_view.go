@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -50,6 +51,31 @@ func (p *ZhiPuViewProvider) PrepareRequest(prompt string, options map[string]int
 	return json.Marshal(requestBody)
 }
 
+// GenerateImage implements ImageProvider for cogview-3 and other zhipu_view
+// models, carrying through the content-filter metadata that ParseResponse
+// discards.
+func (p *ZhiPuViewProvider) GenerateImage(ctx context.Context, prompt string, opts ImageOptions) ([]ImageResult, error) {
+	requestBody := map[string]interface{}{
+		"model":  p.model,
+		"prompt": prompt,
+	}
+	for k, v := range opts.toMap() {
+		requestBody[k] = v
+	}
+
+	body, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing image request: %w", err)
+	}
+
+	respBody, err := imageHTTPRequest(ctx, p.Endpoint(), p.Headers(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeImageResponse(respBody)
+}
+
 func (p *ZhiPuViewProvider) ParseResponse(body []byte) (string, error) {
 	var response struct {
 		Created int64 `json:"created"`